@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"path/filepath"
+)
+
+// Size guardrails applied when streaming attachments into a multipart request.
+// These exist so a runaway io.Reader (e.g. an unbounded pipe) can't exhaust
+// client memory or produce a payload the backend would reject anyway.
+const (
+	// MaxAttachmentSize is the maximum number of bytes read from a single
+	// Attachment.Data before Send aborts with ErrAttachmentTooLarge.
+	MaxAttachmentSize = 25 << 20 // 25 MiB
+
+	// MaxTotalPayloadSize is the maximum combined size of all attachments
+	// in a single EmailReq before Send aborts with ErrPayloadTooLarge.
+	MaxTotalPayloadSize = 40 << 20 // 40 MiB
+)
+
+// ErrAttachmentTooLarge is returned when a single attachment exceeds MaxAttachmentSize.
+var ErrAttachmentTooLarge = errors.New("mailer: attachment exceeds maximum size")
+
+// ErrPayloadTooLarge is returned when the combined size of all attachments
+// exceeds MaxTotalPayloadSize.
+var ErrPayloadTooLarge = errors.New("mailer: total attachment payload exceeds maximum size")
+
+// Attachment represents a single file to be delivered alongside an email,
+// either as a regular attachment or, when Inline is true, referenced from
+// the HTML body via "cid:<ContentID>".
+//
+// Data is read once when the request is sent; callers should not reuse an
+// Attachment across multiple calls to Client.Send. For this reason
+// SendContext never retries a request that carries attachments - see
+// SendContext's doc comment.
+type Attachment struct {
+	Filename    string    // Name of the file as it should appear to the recipient
+	ContentType string    // MIME type, e.g. "image/png"; guessed from Filename if empty
+	Inline      bool      // True to reference the attachment from the HTML body via its ContentID
+	ContentID   string    // Used to reference an inline attachment as "cid:<ContentID>"
+	Data        io.Reader // Source of the attachment's bytes
+}
+
+// Attach appends a regular (non-inline) attachment to the request and
+// returns a pointer to it so callers can further adjust ContentType.
+func (r *EmailReq) Attach(name string, data io.Reader) *Attachment {
+	a := Attachment{
+		Filename:    name,
+		ContentType: contentTypeFor(name),
+		Data:        data,
+	}
+	r.Attachments = append(r.Attachments, a)
+	return &r.Attachments[len(r.Attachments)-1]
+}
+
+// AttachInline appends an inline attachment referenced from the HTML body
+// via "cid:<cid>", and returns a pointer to it so callers can further
+// adjust ContentType.
+func (r *EmailReq) AttachInline(name, cid string, data io.Reader) *Attachment {
+	a := Attachment{
+		Filename:    name,
+		ContentType: contentTypeFor(name),
+		Inline:      true,
+		ContentID:   cid,
+		Data:        data,
+	}
+	r.Attachments = append(r.Attachments, a)
+	return &r.Attachments[len(r.Attachments)-1]
+}
+
+func contentTypeFor(filename string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(filename)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// attachmentMeta mirrors Attachment's non-stream fields for inclusion in the
+// multipart request's "metadata" part; the mailer backend matches each entry
+// to its file part by index.
+type attachmentMeta struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Inline      bool   `json:"inline"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+// limitedCopy copies from src to dst, aborting with err if more than max
+// bytes would be written.
+func limitedCopy(dst io.Writer, src io.Reader, max int64, err error) (int64, error) {
+	n, copyErr := io.Copy(dst, io.LimitReader(src, max+1))
+	if copyErr != nil {
+		return n, copyErr
+	}
+	if n > max {
+		return n, err
+	}
+	return n, nil
+}
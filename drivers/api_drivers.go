@@ -0,0 +1,183 @@
+package drivers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	mailer "github.com/yourusername/mailer-client"
+)
+
+// These drivers are minimal stubs covering the common case - ToList,
+// Subject, and a single HTML or plain-text Body. They do not yet support
+// Attachments, Personalizations, or CcList/BccList.
+
+// Mailgun sends email through Mailgun's messages API.
+type Mailgun struct {
+	Domain string
+	APIKey string
+	Client *http.Client
+}
+
+// NewMailgun returns a Mailgun driver for the given sending domain and API key.
+func NewMailgun(domain, apiKey string) *Mailgun {
+	return &Mailgun{Domain: domain, APIKey: apiKey, Client: http.DefaultClient}
+}
+
+// Send implements mailer.Mailer.
+func (m *Mailgun) Send(ctx context.Context, req mailer.EmailReq) (mailer.Result, error) {
+	form := url.Values{}
+	form.Set("from", fmt.Sprintf("%s <%s>", req.FromName, req.FromEmail))
+	form.Set("to", strings.Join(req.ToList, ","))
+	form.Set("subject", req.Subject)
+	if req.Html {
+		form.Set("html", req.Body)
+	} else {
+		form.Set("text", req.Body)
+	}
+
+	endpoint := fmt.Sprintf("https://api.mailgun.net/v3/%s/messages", m.Domain)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	httpReq.SetBasicAuth("api", m.APIKey)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var body struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := doJSON(m.Client, httpReq, &body); err != nil {
+		return mailer.Result{}, err
+	}
+	return mailer.Result{MessageID: body.ID}, nil
+}
+
+// SendGrid sends email through SendGrid's mail/send API.
+type SendGrid struct {
+	APIKey string
+	Client *http.Client
+}
+
+// NewSendGrid returns a SendGrid driver authenticating with apiKey.
+func NewSendGrid(apiKey string) *SendGrid {
+	return &SendGrid{APIKey: apiKey, Client: http.DefaultClient}
+}
+
+// Send implements mailer.Mailer.
+func (s *SendGrid) Send(ctx context.Context, req mailer.EmailReq) (mailer.Result, error) {
+	contentType := "text/plain"
+	if req.Html {
+		contentType = "text/html"
+	}
+
+	payload := map[string]any{
+		"personalizations": []map[string]any{
+			{"to": toAddresses(req.ToList)},
+		},
+		"from":    map[string]string{"email": req.FromEmail, "name": req.FromName},
+		"subject": req.Subject,
+		"content": []map[string]string{
+			{"type": contentType, "value": req.Body},
+		},
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.sendgrid.com/v3/mail/send", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	res, err := s.Client.Do(httpReq)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return mailer.Result{}, fmt.Errorf("sendgrid: unexpected status %s", res.Status)
+	}
+	// SendGrid returns the message ID in the X-Message-Id response header, not the body.
+	return mailer.Result{MessageID: res.Header.Get("X-Message-Id")}, nil
+}
+
+// SparkPost sends email through SparkPost's transmissions API.
+type SparkPost struct {
+	APIKey string
+	Client *http.Client
+}
+
+// NewSparkPost returns a SparkPost driver authenticating with apiKey.
+func NewSparkPost(apiKey string) *SparkPost {
+	return &SparkPost{APIKey: apiKey, Client: http.DefaultClient}
+}
+
+// Send implements mailer.Mailer.
+func (sp *SparkPost) Send(ctx context.Context, req mailer.EmailReq) (mailer.Result, error) {
+	content := map[string]string{"subject": req.Subject, "from": req.FromEmail}
+	if req.Html {
+		content["html"] = req.Body
+	} else {
+		content["text"] = req.Body
+	}
+
+	payload := map[string]any{
+		"recipients": toAddresses(req.ToList),
+		"content":    content,
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.sparkpost.com/api/v1/transmissions", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	httpReq.Header.Set("Authorization", sp.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	var body struct {
+		Results struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	if err := doJSON(sp.Client, httpReq, &body); err != nil {
+		return mailer.Result{}, err
+	}
+	return mailer.Result{MessageID: body.Results.ID}, nil
+}
+
+func toAddresses(addrs []string) []map[string]string {
+	out := make([]map[string]string, len(addrs))
+	for i, a := range addrs {
+		out[i] = map[string]string{"email": a}
+	}
+	return out
+}
+
+// doJSON performs req and decodes a JSON response body into out, treating
+// any non-2xx status as an error.
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("drivers: decode response: %w", err)
+	}
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("drivers: unexpected status %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Package drivers provides mailer.Mailer implementations for transports
+// other than the built-in Bakiverse HTTP API: direct SMTP, and minimal
+// stubs for a few common third-party JSON APIs.
+package drivers
+
+import (
+	"fmt"
+
+	mailer "github.com/yourusername/mailer-client"
+)
+
+// Driver names a transport that Config.Driver selects for New.
+type Driver string
+
+const (
+	DriverBakiverse Driver = "bakiverse" // The built-in Bakiverse HTTP API (mailer.Client)
+	DriverSMTP      Driver = "smtp"      // Direct SMTP delivery with STARTTLS
+	DriverMailgun   Driver = "mailgun"   // Mailgun's messages API
+	DriverSendGrid  Driver = "sendgrid"  // SendGrid's mail/send API
+	DriverSparkPost Driver = "sparkpost" // SparkPost's transmissions API
+)
+
+// Config selects and configures a transport driver for New. Only the fields
+// relevant to the chosen Driver need to be set.
+type Config struct {
+	Driver Driver
+
+	URL    string       // Bakiverse base URL (DriverBakiverse) or SMTP host:port (DriverSMTP)
+	APIKey string       // API key (DriverMailgun, DriverSendGrid, DriverSparkPost)
+	Domain string       // Sending domain (DriverMailgun)
+	Creds  mailer.Creds // SMTP credentials (DriverSMTP); DriverBakiverse ignores it and sends each EmailReq.Creds
+}
+
+// New builds the Mailer selected by cfg.Driver. An empty Driver defaults to
+// DriverBakiverse for backward compatibility with mailer.NewClient.
+func New(cfg Config) (mailer.Mailer, error) {
+	switch cfg.Driver {
+	case "", DriverBakiverse:
+		return mailer.NewClient(cfg.URL).AsMailer(), nil
+	case DriverSMTP:
+		return NewSMTP(cfg.URL, cfg.Creds), nil
+	case DriverMailgun:
+		return NewMailgun(cfg.Domain, cfg.APIKey), nil
+	case DriverSendGrid:
+		return NewSendGrid(cfg.APIKey), nil
+	case DriverSparkPost:
+		return NewSparkPost(cfg.APIKey), nil
+	default:
+		return nil, fmt.Errorf("mailer/drivers: unknown driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,119 @@
+package drivers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	mailer "github.com/yourusername/mailer-client"
+)
+
+// SMTP sends email directly to a mail server, upgrading the connection with
+// STARTTLS when the server advertises support for it. It does not yet
+// support Attachments or Personalizations.
+type SMTP struct {
+	Addr string    // host:port of the SMTP server
+	Auth smtp.Auth // Optional; set by NewSMTP when creds are provided
+}
+
+// NewSMTP returns an SMTP driver that authenticates with creds using PLAIN
+// auth, if creds.Email is set.
+func NewSMTP(addr string, creds mailer.Creds) *SMTP {
+	s := &SMTP{Addr: addr}
+	if creds.Email != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.Auth = smtp.PlainAuth("", creds.Email, creds.Pwd, host)
+	}
+	return s
+}
+
+// Send implements mailer.Mailer.
+func (s *SMTP) Send(ctx context.Context, req mailer.EmailReq) (mailer.Result, error) {
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	defer conn.Close()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return mailer.Result{}, fmt.Errorf("drivers: starttls: %w", err)
+		}
+	}
+
+	if s.Auth != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(s.Auth); err != nil {
+				return mailer.Result{}, fmt.Errorf("drivers: auth: %w", err)
+			}
+		}
+	}
+
+	if err := c.Mail(req.FromEmail); err != nil {
+		return mailer.Result{}, err
+	}
+	for _, to := range recipients(req) {
+		if err := c.Rcpt(to); err != nil {
+			return mailer.Result{}, err
+		}
+	}
+
+	wc, err := c.Data()
+	if err != nil {
+		return mailer.Result{}, err
+	}
+	if _, err := wc.Write(buildMessage(req)); err != nil {
+		wc.Close()
+		return mailer.Result{}, err
+	}
+	if err := wc.Close(); err != nil {
+		return mailer.Result{}, err
+	}
+
+	return mailer.Result{}, c.Quit()
+}
+
+func recipients(req mailer.EmailReq) []string {
+	all := make([]string, 0, len(req.ToList)+len(req.CcList)+len(req.BccList))
+	all = append(all, req.ToList...)
+	all = append(all, req.CcList...)
+	all = append(all, req.BccList...)
+	return all
+}
+
+// buildMessage renders req as an RFC 5322 message ready for the SMTP DATA command.
+func buildMessage(req mailer.EmailReq) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s <%s>\r\n", req.FromName, req.FromEmail)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(req.ToList, ", "))
+	if len(req.CcList) > 0 {
+		fmt.Fprintf(&b, "Cc: %s\r\n", strings.Join(req.CcList, ", "))
+	}
+	if req.ReplyTo != "" {
+		fmt.Fprintf(&b, "Reply-To: %s\r\n", req.ReplyTo)
+	}
+	fmt.Fprintf(&b, "Subject: %s\r\n", req.Subject)
+	if req.Html {
+		b.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	} else {
+		b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	}
+	b.WriteString("\r\n")
+	b.WriteString(req.Body)
+	return []byte(b.String())
+}
@@ -0,0 +1,122 @@
+package mailer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize bounds how much of a response body Send reads when
+// classifying a non-2xx response, so a giant error page can't exhaust
+// client memory.
+const maxErrorBodySize = 1 << 20 // 1 MiB
+
+// Sentinel errors classified from the backend's HTTP status code. Check for
+// one with errors.Is; for details beyond the sentinel, use errors.As with
+// *StatusError (ErrUnauthorized, ErrBadRequest, ErrServer), *RateLimitError
+// (ErrRateLimited), or *TransportError (ErrTransport).
+var (
+	ErrUnauthorized = errors.New("mailer: unauthorized")
+	ErrBadRequest   = errors.New("mailer: bad request")
+	ErrServer       = errors.New("mailer: server error")
+	ErrRateLimited  = errors.New("mailer: rate limited")
+	ErrTransport    = errors.New("mailer: transport error")
+)
+
+// StatusError reports a non-2xx response from the mailer backend, with the
+// status code and a best-effort extraction of the backend's error message.
+type StatusError struct {
+	Sentinel   error // One of ErrUnauthorized, ErrBadRequest, ErrServer; ErrRateLimited inside a RateLimitError
+	StatusCode int
+	Message    string // Decoded from EmailRes.Message, or a raw body snippet if the body wasn't JSON
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s (status %d)", e.Sentinel, e.StatusCode)
+	}
+	return fmt.Sprintf("%s (status %d): %s", e.Sentinel, e.StatusCode, e.Message)
+}
+
+// Unwrap exposes Sentinel so errors.Is(err, ErrServer) etc. works.
+func (e *StatusError) Unwrap() error { return e.Sentinel }
+
+// RateLimitError is returned for 429 responses. It embeds StatusError (whose
+// Sentinel is ErrRateLimited) and adds RetryAfter, parsed from the
+// Retry-After header when present.
+type RateLimitError struct {
+	StatusError
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter <= 0 {
+		return e.StatusError.Error()
+	}
+	return fmt.Sprintf("%s, retry after %s", e.StatusError.Error(), e.RetryAfter)
+}
+
+// TransportError wraps a lower-level failure from the HTTP round trip
+// itself (dial failures, timeouts, TLS errors) rather than an HTTP
+// response, so it can still be matched with errors.Is(err, ErrTransport)
+// while errors.As reaches the underlying network error.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string        { return fmt.Sprintf("mailer: transport error: %s", e.Err) }
+func (e *TransportError) Unwrap() error        { return e.Err }
+func (e *TransportError) Is(target error) bool { return target == ErrTransport }
+
+// classifyStatus turns res into an error if it's not a 2xx response,
+// reading at most maxErrorBodySize bytes of the body to build Message. It
+// always closes res.Body.
+func classifyStatus(res *http.Response) error {
+	defer res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return decodeEmailRes(io.LimitReader(res.Body, maxErrorBodySize))
+	}
+
+	message := bestEffortMessage(res.Body)
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden:
+		return &StatusError{Sentinel: ErrUnauthorized, StatusCode: res.StatusCode, Message: message}
+	case res.StatusCode == http.StatusTooManyRequests:
+		return &RateLimitError{
+			StatusError: StatusError{Sentinel: ErrRateLimited, StatusCode: res.StatusCode, Message: message},
+			RetryAfter:  parseRetryAfter(res.Header.Get("Retry-After")),
+		}
+	case res.StatusCode >= 500:
+		return &StatusError{Sentinel: ErrServer, StatusCode: res.StatusCode, Message: message}
+	default:
+		return &StatusError{Sentinel: ErrBadRequest, StatusCode: res.StatusCode, Message: message}
+	}
+}
+
+// bestEffortMessage tries to decode body as an EmailRes and return its
+// Message; if that fails, it falls back to a short raw snippet so callers
+// at least see something from a non-JSON error page.
+func bestEffortMessage(body io.Reader) string {
+	data, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+
+	var resp EmailRes
+	if err := json.Unmarshal(data, &resp); err == nil && resp.Message != "" {
+		return resp.Message
+	}
+
+	const maxSnippet = 200
+	snippet := strings.TrimSpace(string(data))
+	if len(snippet) > maxSnippet {
+		snippet = snippet[:maxSnippet] + "..."
+	}
+	return snippet
+}
@@ -4,7 +4,7 @@
 //
 // # Usage
 //
-//	import "github.com/yourusername/mailer-client/mailer"
+//	import "github.com/yourusername/mailer-client"
 //
 //	client := mailer.NewClient("https://mailer.bakiverse.com")
 //	err := client.Send(mailer.EmailReq{
@@ -26,27 +26,58 @@ package mailer
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"time"
+
+	"github.com/yourusername/mailer-client/template"
 )
 
 // Client holds the configuration to interact with the remote Mailer service.
 // You can optionally provide your own *http.Client, otherwise a default one with a 10s timeout is used.
 type Client struct {
-	BaseURL string       // BaseURL of the Mailer API (e.g. https://mailer.bakiverse.com)
-	Client  *http.Client // Optional custom HTTP client
+	BaseURL   string             // BaseURL of the Mailer API (e.g. https://mailer.bakiverse.com)
+	Client    *http.Client       // Optional custom HTTP client
+	Templates *template.Registry // Optional named templates; see RegisterHTMLTemplate and RegisterTextTemplate
+	Retry     RetryPolicy        // Retry behavior for SendContext; zero value falls back to DefaultRetryPolicy
 }
 
 // EmailReq represents the full request body for sending an email.
 type EmailReq struct {
-	Creds    Creds    `json:"creds"`      // Authentication credentials for the mailer (email + password)
-	ToList   []string `json:"to_list"`    // List of recipient email addresses
-	Subject  string   `json:"subject"`    // Subject of the email
-	Body     string   `json:"body"`       // Content of the email (HTML or plain text)
-	Html     bool     `json:"html"`       // Indicates whether Body is HTML (true) or plain text (false)
-	FromName string   `json:"from_name"`  // Display name of the sender
+	Creds            Creds             `json:"creds"`                      // Authentication credentials for the mailer (email + password)
+	ToList           []string          `json:"to_list"`                    // List of recipient email addresses
+	CcList           []string          `json:"cc_list,omitempty"`          // List of CC'd recipient email addresses
+	BccList          []string          `json:"bcc_list,omitempty"`         // List of BCC'd recipient email addresses
+	ReplyTo          string            `json:"reply_to,omitempty"`         // Address replies should be sent to, if different from FromEmail
+	Subject          string            `json:"subject"`                    // Subject of the email
+	Body             string            `json:"body"`                       // Content of the email (HTML or plain text)
+	Html             bool              `json:"html"`                       // Indicates whether Body is HTML (true) or plain text (false)
+	FromName         string            `json:"from_name"`                  // Display name of the sender
+	FromEmail        string            `json:"from_email,omitempty"`       // Email address of the sender
+	Personalizations []Personalization `json:"personalizations,omitempty"` // Per-recipient overrides; see Personalization
+	Attachments      []Attachment      `json:"-"`                          // Files to deliver alongside the email; see Attach and AttachInline
+
+	PlainBody    string         `json:"plain_body,omitempty"`    // Plain-text alternative to Body; sent alongside Body as multipart/alternative when both are set
+	Alternative  bool           `json:"alternative,omitempty"`   // Set automatically when both Body and PlainBody are populated
+	TemplateID   string         `json:"template_id,omitempty"`   // ID of a template registered on Client.Templates
+	TemplateVars map[string]any `json:"template_vars,omitempty"` // Variables passed to the TemplateID template when rendering
+
+	IdempotencyKey string `json:"-"` // Sent as the Idempotency-Key header; auto-generated if empty
+}
+
+// Personalization overrides parts of an EmailReq for a subset of recipients,
+// and supplies Vars for substitution into Subject and Body (e.g. "{{name}}").
+// When ToList is empty, EmailReq.ToList is used instead; the same applies to
+// Subject falling back to EmailReq.Subject.
+type Personalization struct {
+	ToList  []string          `json:"to_list,omitempty"` // Overrides EmailReq.ToList for this personalization, if set
+	Subject string            `json:"subject,omitempty"` // Overrides EmailReq.Subject for this personalization, if set
+	Vars    map[string]string `json:"vars,omitempty"`    // Substitution variables applied to Subject and Body
 }
 
 // EmailRes describes the JSON structure returned by the mailer API after sending an email.
@@ -67,31 +98,201 @@ func NewClient(baseURL string) *Client {
 	return &Client{
 		BaseURL: baseURL,
 		Client:  &http.Client{Timeout: 10 * time.Second},
+		Retry:   DefaultRetryPolicy,
 	}
 }
 
 // Send sends an email request to the configured Mailer API.
 //
+// When req has no Attachments, the request is sent as a single JSON body.
+// Otherwise it is streamed as multipart/form-data: a "metadata" field
+// carrying the JSON-encoded request (attachment data replaced by per-file
+// descriptors) followed by one file part per attachment, named "file0",
+// "file1", etc. in Attachments order. The mailer backend must match file
+// parts to descriptors by that index. Attachment data is streamed directly
+// from each Attachment.Data into the request body rather than buffered, but
+// is still bounded by MaxAttachmentSize and MaxTotalPayloadSize.
+//
 // It returns an error if the HTTP request fails, or if the backend indicates a failed delivery.
 func (c *Client) Send(req EmailReq) error {
+	return c.SendContext(context.Background(), req)
+}
+
+// SendContext is Send with a caller-provided context: ctx bounds both the
+// initial attempt and any retries, and is passed through to the underlying
+// HTTP request so cancellation aborts an in-flight send.
+//
+// Failed sends are retried per c.Retry (DefaultRetryPolicy if c.Retry is
+// unset), but only for network errors and 5xx/429 responses; the server is
+// expected to deduplicate retries within a window keyed by the
+// Idempotency-Key header, which is generated from req.IdempotencyKey or,
+// if empty, a random UUIDv4.
+//
+// Requests with attachments are never retried: Attachment.Data is an
+// io.Reader that's drained on the first attempt, so a second attempt would
+// silently stream empty or partial files instead of failing loudly.
+func (c *Client) SendContext(ctx context.Context, req EmailReq) error {
+	if err := c.resolveTemplate(&req); err != nil {
+		return err
+	}
+	if err := validateAddresses(req); err != nil {
+		return err
+	}
+	if req.Body != "" && req.PlainBody != "" {
+		req.Alternative = true
+	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = newIdempotencyKey()
+	}
+
+	policy := c.Retry
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+	if len(req.Attachments) > 0 {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			delay := nextDelay(policy, attempt-1)
+			if wait, ok := retryAfterOf(lastErr); ok {
+				delay = wait
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		var err error
+		if len(req.Attachments) > 0 {
+			err = c.sendMultipart(ctx, req)
+		} else {
+			err = c.sendJSON(ctx, req)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			return err
+		}
+	}
+	return lastErr
+}
+
+func (c *Client) sendJSON(ctx context.Context, req EmailReq) error {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return err
 	}
 
-	res, err := c.Client.Post(c.BaseURL+"/send", "application/json", bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/send", bytes.NewReader(jsonData))
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", req.IdempotencyKey)
 
+	res, err := c.Client.Do(httpReq)
+	if err != nil {
+		return &TransportError{Err: err}
+	}
+	return classifyStatus(res)
+}
+
+func (c *Client) sendMultipart(ctx context.Context, req EmailReq) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	contentType := mw.FormDataContentType()
+
+	go func() {
+		if err := writeMultipartReq(mw, req); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/send", pr)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Idempotency-Key", req.IdempotencyKey)
+
+	res, err := c.Client.Do(httpReq)
+	if err != nil {
+		return &TransportError{Err: err}
+	}
+	return classifyStatus(res)
+}
+
+// writeMultipartReq writes req's metadata part followed by one file part per
+// attachment, enforcing MaxAttachmentSize and MaxTotalPayloadSize as it goes.
+func writeMultipartReq(mw *multipart.Writer, req EmailReq) error {
+	metaPart, err := mw.CreateFormField("metadata")
+	if err != nil {
+		return err
+	}
+
+	meta := struct {
+		EmailReq
+		Attachments []attachmentMeta `json:"attachments"`
+	}{EmailReq: req}
+	for _, a := range req.Attachments {
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = contentTypeFor(a.Filename)
+		}
+		meta.Attachments = append(meta.Attachments, attachmentMeta{
+			Filename:    a.Filename,
+			ContentType: contentType,
+			Inline:      a.Inline,
+			ContentID:   a.ContentID,
+		})
+	}
+	if err := json.NewEncoder(metaPart).Encode(meta); err != nil {
+		return err
+	}
+
+	var total int64
+	for i, a := range req.Attachments {
+		remaining := int64(MaxTotalPayloadSize) - total
+		if remaining <= 0 {
+			return ErrPayloadTooLarge
+		}
+		limit := int64(MaxAttachmentSize)
+		limitErr := error(ErrAttachmentTooLarge)
+		if remaining < limit {
+			limit = remaining
+			limitErr = ErrPayloadTooLarge
+		}
+
+		fw, err := mw.CreateFormFile(fmt.Sprintf("file%d", i), a.Filename)
+		if err != nil {
+			return err
+		}
+		n, err := limitedCopy(fw, a.Data, limit, limitErr)
+		if err != nil {
+			return err
+		}
+		total += n
+	}
+
+	return nil
+}
+
+func decodeEmailRes(body io.Reader) error {
 	var resp EmailRes
-	if err = json.NewDecoder(res.Body).Decode(&resp); err != nil {
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
 		return errors.New("email sent but failed to decode response")
 	}
 	if !resp.Success {
 		return errors.New("failed to send email: " + resp.Message)
 	}
-
 	return nil
 }
@@ -0,0 +1,34 @@
+package mailer
+
+import "context"
+
+// Result carries provider-specific details about a successfully sent email,
+// such as a message ID useful for tracing delivery across systems.
+type Result struct {
+	MessageID string // Provider-assigned message ID, if the driver returns one
+}
+
+// Mailer is implemented by every transport driver: Client (the built-in
+// Bakiverse HTTP API, through AsMailer), and the SMTP and third-party API drivers under
+// mailer/drivers. It lets callers swap transports without changing how they
+// build an EmailReq.
+type Mailer interface {
+	Send(ctx context.Context, req EmailReq) (Result, error)
+}
+
+// AsMailer adapts c to the Mailer interface, for use wherever a Mailer is
+// expected instead of a concrete *Client.
+func (c *Client) AsMailer() Mailer {
+	return clientMailer{c}
+}
+
+// clientMailer adapts Client's SendContext(ctx, req) error method to the Mailer
+// interface's Send(ctx, req) (Result, error) shape.
+type clientMailer struct {
+	*Client
+}
+
+func (m clientMailer) Send(ctx context.Context, req EmailReq) (Result, error) {
+	err := m.Client.SendContext(ctx, req)
+	return Result{}, err
+}
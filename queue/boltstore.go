@@ -0,0 +1,103 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var itemsBucket = []byte("items")
+
+// BoltStore is the default Store: a single BoltDB file holding one record
+// per Item, so pending mail survives a process crash without the many
+// small files FileStore leaves behind.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(itemsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// Save implements Store.
+func (s *BoltStore) Save(item Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(itemsBucket).Put([]byte(item.ID), data)
+	})
+}
+
+// Get implements Store.
+func (s *BoltStore) Get(id string) (Item, error) {
+	var item Item
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(itemsBucket).Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("queue: item %q not found", id)
+		}
+		return json.Unmarshal(data, &item)
+	})
+	return item, err
+}
+
+// Update implements Store, reading the current Item, applying fn, and
+// persisting the result within the same transaction.
+func (s *BoltStore) Update(id string, fn func(*Item)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(itemsBucket)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return fmt.Errorf("queue: item %q not found", id)
+		}
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		fn(&item)
+		updated, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(item.ID), updated)
+	})
+}
+
+// Pending implements Store, returning every Item still in the Pending or
+// Retrying state, e.g. to resume a queue after a restart.
+func (s *BoltStore) Pending() ([]Item, error) {
+	var items []Item
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(itemsBucket).ForEach(func(_, v []byte) error {
+			var item Item
+			if err := json.Unmarshal(v, &item); err != nil {
+				return err
+			}
+			if item.Status == Pending || item.Status == Retrying {
+				items = append(items, item)
+			}
+			return nil
+		})
+	})
+	return items, err
+}
@@ -0,0 +1,114 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a dependency-light Store alternative to the default
+// BoltStore: one JSON file per Item in Dir, written to a temp file and
+// renamed into place so a crash mid-write can't corrupt the outbox.
+type FileStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating it if necessary.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+// Save implements Store.
+func (s *FileStore) Save(item Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(item)
+}
+
+func (s *FileStore) write(item Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	tmp := s.path(item.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(item.ID))
+}
+
+// Get implements Store.
+func (s *FileStore) Get(id string) (Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return Item{}, err
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// Update implements Store, reading the current Item, applying fn, and
+// persisting the result.
+func (s *FileStore) Update(id string, fn func(*Item)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return err
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	fn(&item)
+	return s.write(item)
+}
+
+// Pending implements Store, returning every Item still in the Pending or
+// Retrying state, e.g. to resume a queue after a restart.
+func (s *FileStore) Pending() ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.Dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("queue: read %s: %w", e.Name(), err)
+		}
+		var item Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, err
+		}
+		if item.Status == Pending || item.Status == Retrying {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,270 @@
+// Package queue wraps a mailer.Mailer with an in-process worker pool and a
+// persistent outbox, so callers can enqueue a send without blocking on the
+// network and without losing pending mail if the process crashes.
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	mailer "github.com/yourusername/mailer-client"
+)
+
+// Event is emitted on Queue.Subscribe as an item moves through the queue.
+type Event struct {
+	ID     string
+	Status Status
+	Err    error // Set for Retrying and Failed events
+}
+
+// Options configures a Queue. Zero values fall back to sensible defaults.
+type Options struct {
+	Concurrency int   // Worker goroutines; defaults to 1
+	RatePerSec  int   // Max sends started per second across all workers; 0 means unlimited
+	QueueSize   int   // Bounded channel capacity; defaults to 64
+	MaxAttempts int   // Attempts before an item is marked Failed and no longer retried; defaults to 5
+	Store       Store // Defaults to a BoltStore at os.TempDir()/mailer-outbox/outbox.db
+}
+
+// Queue is a bounded, in-process worker pool around a mailer.Mailer backed
+// by a persistent Store.
+type Queue struct {
+	mailer mailer.Mailer
+	store  Store
+	opts   Options
+
+	jobs   chan string
+	events chan Event
+	ticker *time.Ticker
+
+	mu     sync.Mutex // guards closed
+	closed bool
+
+	wg      sync.WaitGroup // worker goroutines
+	retryWg sync.WaitGroup // pending scheduleRetry timers
+}
+
+// ErrQueueClosed is returned by Enqueue once Close has been called.
+var ErrQueueClosed = errors.New("queue: closed")
+
+// ErrAttachmentsNotSupported is returned by Enqueue for a request carrying
+// attachments: Attachment.Data is an io.Reader, which can't be persisted by
+// a Store, so an attachment would be silently lost on a crash-then-resume.
+// Send requests with attachments directly through a mailer.Mailer instead.
+var ErrAttachmentsNotSupported = errors.New("queue: attachments are not supported, send directly through a mailer.Mailer instead")
+
+// NewQueue starts a Queue around m, resuming any Pending or Retrying items
+// found in opts.Store from a previous run.
+func NewQueue(m mailer.Mailer, opts Options) (*Queue, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 64
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.Store == nil {
+		dir := filepath.Join(os.TempDir(), "mailer-outbox")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		store, err := NewBoltStore(filepath.Join(dir, "outbox.db"))
+		if err != nil {
+			return nil, err
+		}
+		opts.Store = store
+	}
+
+	q := &Queue{
+		mailer: m,
+		store:  opts.Store,
+		opts:   opts,
+		jobs:   make(chan string, opts.QueueSize),
+		events: make(chan Event, opts.QueueSize),
+	}
+	if opts.RatePerSec > 0 {
+		q.ticker = time.NewTicker(time.Second / time.Duration(opts.RatePerSec))
+	}
+
+	pending, err := opts.Store.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < opts.Concurrency; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	for _, item := range pending {
+		q.enqueue(item.ID)
+	}
+
+	return q, nil
+}
+
+// Enqueue persists req to the Store and schedules it for delivery, returning
+// its id for later use with Status. req must not carry attachments; see
+// ErrAttachmentsNotSupported.
+func (q *Queue) Enqueue(req mailer.EmailReq) (string, error) {
+	if len(req.Attachments) > 0 {
+		return "", ErrAttachmentsNotSupported
+	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = mailer.NewIdempotencyKey()
+	}
+
+	id := generateID()
+	item := Item{
+		ID:             id,
+		Req:            req,
+		IdempotencyKey: req.IdempotencyKey,
+		Status:         Pending,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := q.store.Save(item); err != nil {
+		return "", err
+	}
+
+	if !q.enqueue(id) {
+		return "", ErrQueueClosed
+	}
+	return id, nil
+}
+
+// enqueue sends id to jobs unless the queue has been closed, returning
+// whether the send happened. It's the only path that feeds jobs (NewQueue's
+// initial backlog goes through it too), so Close can stop new sends with a single flag
+// flip instead of relying on the channel itself being closed.
+func (q *Queue) enqueue(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	q.jobs <- id
+	return true
+}
+
+// Status returns the current state of a previously enqueued item.
+func (q *Queue) Status(id string) (Item, error) {
+	return q.store.Get(id)
+}
+
+// Subscribe returns a channel of delivery events. Events are dropped, not
+// blocked on, if the channel isn't drained quickly enough - Status remains
+// the source of truth.
+func (q *Queue) Subscribe() <-chan Event {
+	return q.events
+}
+
+// Close stops accepting new jobs and waits for in-flight workers, and any
+// retry timers they scheduled, to finish.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.retryWg.Wait() // no scheduleRetry call still pending can send to jobs now
+	close(q.jobs)
+	q.wg.Wait()
+	if q.ticker != nil {
+		q.ticker.Stop()
+	}
+	close(q.events)
+}
+
+func (q *Queue) worker() {
+	defer q.wg.Done()
+	for id := range q.jobs {
+		q.process(id)
+	}
+}
+
+func (q *Queue) process(id string) {
+	if q.ticker != nil {
+		<-q.ticker.C
+	}
+
+	item, err := q.store.Get(id)
+	if err != nil {
+		return
+	}
+	// item.Req.IdempotencyKey never round-trips through the Store (it's
+	// excluded from JSON), so restore it from the top-level field that does
+	// - otherwise every attempt, including ones after a crash, would send
+	// with a fresh key and lose the backend's dedup guarantee.
+	item.Req.IdempotencyKey = item.IdempotencyKey
+
+	_, sendErr := q.mailer.Send(context.Background(), item.Req)
+	item.Attempts++
+	item.UpdatedAt = time.Now()
+
+	switch {
+	case sendErr == nil:
+		item.Status = Sent
+		q.updateAndEmit(id, item, Event{ID: id, Status: Sent})
+	case item.Attempts >= q.opts.MaxAttempts:
+		item.Status = Failed
+		item.LastError = sendErr.Error()
+		q.updateAndEmit(id, item, Event{ID: id, Status: Failed, Err: sendErr})
+	default:
+		item.Status = Retrying
+		item.LastError = sendErr.Error()
+		q.updateAndEmit(id, item, Event{ID: id, Status: Retrying, Err: sendErr})
+		q.scheduleRetry(id, retryDelay(item.Attempts))
+	}
+}
+
+// scheduleRetry re-enqueues id after delay. Unlike a direct q.jobs <- id,
+// this never runs on a worker goroutine: a worker that blocked on q.jobs
+// directly could deadlock with no free worker left to drain it, or panic by
+// sending on q.jobs after Close closed it. Close waits out retryWg before
+// closing q.jobs, so a timer firing mid-shutdown is a no-op instead of a
+// panic.
+func (q *Queue) scheduleRetry(id string, delay time.Duration) {
+	q.retryWg.Add(1)
+	go func() {
+		defer q.retryWg.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		<-timer.C
+		q.enqueue(id)
+	}()
+}
+
+// retryDelay returns the backoff before redelivering an item that just
+// failed its attempt'th try: 2s per attempt so far, capped at 30s.
+func retryDelay(attempt int) time.Duration {
+	d := time.Duration(attempt) * 2 * time.Second
+	if d > 30*time.Second {
+		d = 30 * time.Second
+	}
+	return d
+}
+
+func (q *Queue) updateAndEmit(id string, item Item, evt Event) {
+	q.store.Update(id, func(i *Item) { *i = item })
+	select {
+	case q.events <- evt:
+	default:
+	}
+}
+
+// generateID returns a random hex identifier for a newly enqueued Item.
+func generateID() string {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("item-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
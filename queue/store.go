@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"time"
+
+	mailer "github.com/yourusername/mailer-client"
+)
+
+// Status is the lifecycle state of a queued Item.
+type Status string
+
+const (
+	Pending  Status = "pending"  // Enqueued, not yet attempted
+	Sent     Status = "sent"     // Delivered successfully
+	Retrying Status = "retrying" // Failed at least once, will be retried
+	Failed   Status = "failed"   // Gave up after MaxAttempts; the Item stays in the Store, not in a separate bucket
+)
+
+// Item is a single queued send, persisted by a Store so it survives a
+// process crash between enqueue and delivery.
+//
+// IdempotencyKey is tracked separately from Req because
+// mailer.EmailReq.IdempotencyKey is excluded from JSON (it's sent as a
+// header, not a body field) and so would not otherwise survive a restart -
+// which would silently defeat the backend's dedup guarantee by handing out
+// a fresh key on every redelivery attempt.
+type Item struct {
+	ID             string
+	Req            mailer.EmailReq
+	IdempotencyKey string
+	Status         Status
+	Attempts       int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Store persists queued Items. The default outbox (see NewBoltStore) is a
+// single BoltDB file; FileStore is also available as a simpler,
+// dependency-light alternative, and a SQLite-backed Store can be
+// substituted by implementing this interface instead.
+type Store interface {
+	Save(item Item) error
+	Get(id string) (Item, error)
+	Update(id string, fn func(*Item)) error
+	Pending() ([]Item, error)
+}
@@ -0,0 +1,110 @@
+package mailer
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.SendContext retries a failed send. A zero
+// RetryPolicy (MaxAttempts <= 0) falls back to DefaultRetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts, including the first; 1 disables retries, <= 0 falls back to DefaultRetryPolicy
+	BaseDelay   time.Duration // Delay before the first retry
+	MaxDelay    time.Duration // Upper bound on the backoff before Jitter is applied
+	Jitter      float64       // Fraction of the delay to randomize, e.g. 0.2 for +/-20%
+}
+
+// DefaultRetryPolicy is used by NewClient and whenever Client.Retry is unset.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	Jitter:      0.2,
+}
+
+// isRetryable reports whether err is transient and worth another attempt:
+// a rate-limit or server-error response, or a transport-level failure.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) || errors.Is(err, ErrTransport)
+}
+
+// retryAfterOf extracts the server-requested delay from err, if any.
+func retryAfterOf(err error) (time.Duration, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) && rl.RetryAfter > 0 {
+		return rl.RetryAfter, true
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110 is
+// either a number of seconds or an HTTP-date. Both forms are supported: an
+// RFC 1123 date becomes the time remaining until it. An unparseable or
+// absent value returns 0.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := time.Parse(time.RFC1123, header); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// nextDelay computes the delay before attempt (1-indexed: the delay before
+// the 2nd, 3rd, ... attempt), as exponential backoff from policy.BaseDelay,
+// capped at policy.MaxDelay and randomized by policy.Jitter.
+func nextDelay(policy RetryPolicy, attempt int) time.Duration {
+	backoff := float64(policy.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if ceiling := float64(policy.MaxDelay); ceiling > 0 && backoff > ceiling {
+		backoff = ceiling
+	}
+	if policy.Jitter > 0 {
+		spread := backoff * policy.Jitter
+		backoff += (randFloat()*2 - 1) * spread
+	}
+	if backoff < 0 {
+		backoff = 0
+	}
+	return time.Duration(backoff)
+}
+
+// randFloat returns a random float64 in [0, 1).
+func randFloat() float64 {
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<53))
+	if err != nil {
+		return 0.5
+	}
+	return float64(n.Int64()) / (1 << 53)
+}
+
+// NewIdempotencyKey generates a random UUIDv4 suitable for
+// EmailReq.IdempotencyKey. Callers that need the same key to survive across
+// process restarts (e.g. package queue) should generate it once and persist
+// it themselves, since EmailReq.IdempotencyKey is not part of the request's
+// JSON encoding.
+func NewIdempotencyKey() string {
+	return newIdempotencyKey()
+}
+
+// newIdempotencyKey generates a random UUIDv4 for EmailReq.IdempotencyKey.
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing is effectively unrecoverable for this process;
+		// fall back to a fixed-but-unique-enough value rather than panicking.
+		return fmt.Sprintf("fallback-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
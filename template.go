@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+
+	"github.com/yourusername/mailer-client/template"
+)
+
+// RegisterHTMLTemplate registers an HTML template under id on c.Templates,
+// creating the registry on first use. Reference it from EmailReq.TemplateID.
+func (c *Client) RegisterHTMLTemplate(id string, tmpl *htmltemplate.Template) {
+	if c.Templates == nil {
+		c.Templates = template.NewRegistry()
+	}
+	c.Templates.RegisterHTML(id, tmpl)
+}
+
+// RegisterTextTemplate registers a plain-text template under id on
+// c.Templates, creating the registry on first use. Reference it from
+// EmailReq.TemplateID.
+func (c *Client) RegisterTextTemplate(id string, tmpl *texttemplate.Template) {
+	if c.Templates == nil {
+		c.Templates = template.NewRegistry()
+	}
+	c.Templates.RegisterText(id, tmpl)
+}
+
+// resolveTemplate renders req.TemplateID (if set) against req.TemplateVars
+// using c.Templates, filling in Body and/or PlainBody with the result.
+func (c *Client) resolveTemplate(req *EmailReq) error {
+	if req.TemplateID == "" {
+		return nil
+	}
+	if c.Templates == nil {
+		return fmt.Errorf("mailer: template %q referenced but no templates registered on Client", req.TemplateID)
+	}
+
+	html, plain, err := c.Templates.Render(req.TemplateID, req.TemplateVars)
+	if err != nil {
+		return err
+	}
+	if html != "" {
+		req.Body = html
+		req.Html = true
+	}
+	if plain != "" {
+		req.PlainBody = plain
+	}
+	return nil
+}
+
+// fieldWriter is an io.Writer that appends written bytes to a string field,
+// used by EmailReq.HTMLWriter and EmailReq.PlainWriter.
+type fieldWriter struct {
+	target *string
+}
+
+func (w *fieldWriter) Write(p []byte) (int, error) {
+	*w.target += string(p)
+	return len(p), nil
+}
+
+// HTMLWriter returns an io.Writer that appends to r.Body and marks it as
+// HTML, so callers can render directly into it, e.g.
+// tmpl.ExecuteTemplate(req.HTMLWriter(), "welcome", data).
+func (r *EmailReq) HTMLWriter() io.Writer {
+	r.Html = true
+	return &fieldWriter{target: &r.Body}
+}
+
+// PlainWriter returns an io.Writer that appends to r.PlainBody, the
+// plain-text alternative to Body.
+func (r *EmailReq) PlainWriter() io.Writer {
+	return &fieldWriter{target: &r.PlainBody}
+}
@@ -0,0 +1,66 @@
+// Package template lets a mailer.Client register named HTML and plain-text
+// templates once and render them by ID, mirroring the template_id/template_vars
+// request shape used by services like MailWhale.
+package template
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Registry holds named templates keyed by the ID callers later pass as
+// EmailReq.TemplateID. A given ID may have an HTML template, a plain-text
+// template, or both.
+type Registry struct {
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// NewRegistry returns an empty Registry ready for RegisterHTML/RegisterText.
+func NewRegistry() *Registry {
+	return &Registry{
+		html: make(map[string]*htmltemplate.Template),
+		text: make(map[string]*texttemplate.Template),
+	}
+}
+
+// RegisterHTML associates id with an *html/template.Template used to render
+// the HTML body.
+func (r *Registry) RegisterHTML(id string, tmpl *htmltemplate.Template) {
+	r.html[id] = tmpl
+}
+
+// RegisterText associates id with a *text/template.Template used to render
+// the plain-text body.
+func (r *Registry) RegisterText(id string, tmpl *texttemplate.Template) {
+	r.text[id] = tmpl
+}
+
+// Render executes the template(s) registered under id against vars,
+// returning the rendered HTML body, the rendered plain-text body, or both.
+// It returns an error if id has no registered template at all.
+func (r *Registry) Render(id string, vars any) (html string, plain string, err error) {
+	htmlTmpl, hasHTML := r.html[id]
+	textTmpl, hasText := r.text[id]
+	if !hasHTML && !hasText {
+		return "", "", fmt.Errorf("mailer/template: no template registered for id %q", id)
+	}
+
+	if hasHTML {
+		var buf bytes.Buffer
+		if err := htmlTmpl.Execute(&buf, vars); err != nil {
+			return "", "", err
+		}
+		html = buf.String()
+	}
+	if hasText {
+		var buf bytes.Buffer
+		if err := textTmpl.Execute(&buf, vars); err != nil {
+			return "", "", err
+		}
+		plain = buf.String()
+	}
+	return html, plain, nil
+}
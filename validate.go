@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// ValidationError is returned by Client.Send when one or more email
+// addresses in the request fail to parse, so the caller finds out before
+// the backend rejects the whole batch.
+type ValidationError struct {
+	Addresses []string // The addresses that failed to parse, in the order encountered
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("mailer: invalid email address(es): %s", strings.Join(e.Addresses, ", "))
+}
+
+// validateAddresses checks every address referenced by req - ToList, CcList,
+// BccList, FromEmail, ReplyTo, and each Personalization's ToList - with
+// net/mail.ParseAddress, returning a *ValidationError listing every address
+// that failed to parse.
+func validateAddresses(req EmailReq) error {
+	var bad []string
+
+	check := func(addr string) {
+		if addr == "" {
+			return
+		}
+		if _, err := mail.ParseAddress(addr); err != nil {
+			bad = append(bad, addr)
+		}
+	}
+	checkAll := func(addrs []string) {
+		for _, addr := range addrs {
+			check(addr)
+		}
+	}
+
+	checkAll(req.ToList)
+	checkAll(req.CcList)
+	checkAll(req.BccList)
+	check(req.FromEmail)
+	check(req.ReplyTo)
+	for _, p := range req.Personalizations {
+		checkAll(p.ToList)
+	}
+
+	if len(bad) > 0 {
+		return &ValidationError{Addresses: bad}
+	}
+	return nil
+}